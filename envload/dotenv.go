@@ -0,0 +1,85 @@
+package envload
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DotEnv is a Source backed by a `.env`-style file: one KEY=VALUE pair per
+// line, blank lines and lines starting with `#` ignored, values optionally
+// wrapped in single or double quotes, and `${VAR}` references interpolated
+// against values already loaded from the file or, failing that, the process
+// environment.
+type DotEnv map[string]string
+
+// interpolationRef matches a ${VAR} reference inside a dotenv value.
+var interpolationRef = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// LoadDotEnv reads and parses the .env file at path.
+func LoadDotEnv(path string) (DotEnv, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("envload: loading dotenv file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	values := make(DotEnv)
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, val, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("envload: %s:%d: expected KEY=VALUE, got %q", path, line, text)
+		}
+		key = strings.TrimSpace(key)
+		val = unquote(strings.TrimSpace(val))
+		values[key] = values.interpolate(val)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("envload: reading dotenv file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// Lookup implements Source.
+func (d DotEnv) Lookup(key string) (string, bool) {
+	v, ok := d[key]
+	return v, ok
+}
+
+// interpolate expands every ${VAR} reference in val, preferring a value
+// already loaded from the same file and falling back to the process
+// environment.
+func (d DotEnv) interpolate(val string) string {
+	return interpolationRef.ReplaceAllStringFunc(val, func(ref string) string {
+		name := interpolationRef.FindStringSubmatch(ref)[1]
+		if v, ok := d[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		return ""
+	})
+}
+
+// unquote strips a single matching pair of surrounding single or double
+// quotes, if present; an unquoted value is returned unchanged.
+func unquote(val string) string {
+	if len(val) < 2 {
+		return val
+	}
+	first, last := val[0], val[len(val)-1]
+	if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+		return val[1 : len(val)-1]
+	}
+	return val
+}
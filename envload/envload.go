@@ -0,0 +1,279 @@
+// Package envload populates a struct pointer from environment variables at
+// runtime using reflection. It reads the same `env:"..."` tag vocabulary as
+// the code-generation path in the parser package, so the two can be mixed
+// within a project: generate code for the hot path and fall back to
+// envload.Process for plugins or configs that need to be reloaded without a
+// recompile.
+package envload
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unmarshaler is implemented by types that know how to populate themselves
+// from the raw bytes of an environment variable.
+type Unmarshaler interface {
+	UnmarshalEnv([]byte) error
+}
+
+// defaultSeparator is used to split slice values when the tag does not
+// specify a separator option.
+const defaultSeparator = ","
+
+// tagOptions holds the parsed directives of an `env` tag.
+type tagOptions struct {
+	name       string
+	required   bool
+	hasDefault bool
+	defaultVal string
+	separator  string
+}
+
+// Process populates spec, which must be a non-nil pointer to a struct, from
+// the process environment. It is equivalent to ProcessFrom(spec, OS{}).
+func Process(spec interface{}) error {
+	return ProcessFrom(spec, OS{})
+}
+
+// ProcessFrom populates spec, which must be a non-nil pointer to a struct,
+// by looking up each field's `env` tag across sources in order; the first
+// source that has the key wins. Fields are matched using the `env` struct
+// tag; a field without a tag is skipped. Nested structs and pointer-to-struct
+// fields are walked recursively.
+//
+// ProcessFrom collects every missing required variable and every parse
+// failure before returning, so a single call reports the full set of
+// problems instead of stopping at the first one.
+func ProcessFrom(spec interface{}, sources ...Source) error {
+	v := reflect.ValueOf(spec)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("envload: ProcessFrom requires a non-nil pointer to a struct, got %T", spec)
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("envload: ProcessFrom requires a pointer to a struct, got pointer to %s", v.Kind())
+	}
+
+	if len(sources) == 0 {
+		sources = []Source{OS{}}
+	}
+	chain := Chain(sources)
+	var errs Errors
+	processStruct(v, nil, chain, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// processStruct walks the exported fields of a struct value, resolving each
+// one against source or recursing into nested structs.
+func processStruct(v reflect.Value, path []string, source Source, errs *Errors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported field, nothing we can assign to
+			continue
+		}
+		fieldVal := v.Field(i)
+		fieldPath := append(append([]string{}, path...), field.Name)
+
+		if isStructOrPointerToStruct(fieldVal.Type()) && !implementsUnmarshaler(fieldVal) && fieldVal.Type() != reflect.TypeOf(time.Time{}) {
+			processNested(fieldVal, fieldPath, source, errs)
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok || tag == "-" {
+			continue
+		}
+		opts := parseTag(tag)
+		if err := setField(fieldVal, opts, source); err != nil {
+			errs.add(strings.Join(fieldPath, "."), err)
+		}
+	}
+}
+
+// processNested allocates pointer-to-struct fields on demand and recurses
+// into them, or recurses directly into a plain struct field.
+func processNested(fieldVal reflect.Value, path []string, source Source, errs *Errors) {
+	if fieldVal.Kind() == reflect.Ptr {
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+		}
+		fieldVal = fieldVal.Elem()
+	}
+	processStruct(fieldVal, path, source, errs)
+}
+
+// isStructOrPointerToStruct reports whether t is a struct or a pointer to one.
+func isStructOrPointerToStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// unmarshalerType is the reflect.Type of the Unmarshaler interface, used to
+// statically test whether a field's type satisfies it.
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// implementsUnmarshaler reports whether fieldVal's type satisfies the
+// Unmarshaler interface: directly, for a pointer field whose pointer type
+// has the method (the idiomatic way to implement it), or via its
+// addressable form otherwise.
+func implementsUnmarshaler(fieldVal reflect.Value) bool {
+	if fieldVal.Kind() == reflect.Ptr {
+		return fieldVal.Type().Implements(unmarshalerType)
+	}
+	if fieldVal.CanAddr() {
+		return reflect.PtrTo(fieldVal.Type()).Implements(unmarshalerType)
+	}
+	return false
+}
+
+// unmarshalerFor returns fieldVal as an Unmarshaler, allocating a nil
+// pointer field on demand so its UnmarshalEnv has a valid receiver to
+// populate.
+func unmarshalerFor(fieldVal reflect.Value) (Unmarshaler, bool) {
+	if fieldVal.Kind() == reflect.Ptr {
+		if !fieldVal.Type().Implements(unmarshalerType) {
+			return nil, false
+		}
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+		}
+		u, _ := fieldVal.Interface().(Unmarshaler)
+		return u, true
+	}
+	if fieldVal.CanAddr() {
+		if u, ok := fieldVal.Addr().Interface().(Unmarshaler); ok {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
+// setField resolves a single leaf field from source according to opts,
+// applying defaults and reporting required-but-missing variables.
+func setField(fieldVal reflect.Value, opts tagOptions, source Source) error {
+	raw, present := source.Lookup(opts.name)
+	if !present {
+		if opts.hasDefault {
+			raw, present = opts.defaultVal, true
+		} else if opts.required {
+			return fmt.Errorf("required environment variable %q is not set", opts.name)
+		} else {
+			return nil
+		}
+	}
+
+	if u, ok := unmarshalerFor(fieldVal); ok {
+		if err := u.UnmarshalEnv([]byte(raw)); err != nil {
+			return fmt.Errorf("environment variable %q: %w", opts.name, err)
+		}
+		return nil
+	}
+
+	if fieldVal.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid duration %q: %w", opts.name, raw, err)
+		}
+		fieldVal.SetInt(int64(d))
+		return nil
+	}
+
+	if fieldVal.Type() == reflect.TypeOf(time.Time{}) {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid RFC3339 time %q: %w", opts.name, raw, err)
+		}
+		fieldVal.Set(reflect.ValueOf(ts))
+		return nil
+	}
+
+	if fieldVal.Kind() == reflect.Slice {
+		return setSlice(fieldVal, raw, opts)
+	}
+
+	return setScalar(fieldVal, opts.name, raw)
+}
+
+// setSlice splits raw on opts.separator and assigns each element, converted
+// to the slice's element type, into a freshly allocated slice.
+func setSlice(fieldVal reflect.Value, raw string, opts tagOptions) error {
+	sep := opts.separator
+	if sep == "" {
+		sep = defaultSeparator
+	}
+	parts := strings.Split(raw, sep)
+	out := reflect.MakeSlice(fieldVal.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setScalar(out.Index(i), opts.name, strings.TrimSpace(part)); err != nil {
+			return err
+		}
+	}
+	fieldVal.Set(out)
+	return nil
+}
+
+// setScalar converts raw to fieldVal's kind and assigns it. name is only
+// used to produce a descriptive error.
+func setScalar(fieldVal reflect.Value, name, raw string) error {
+	switch fieldVal.Kind() {
+	case reflect.String:
+		fieldVal.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid bool %q: %w", name, raw, err)
+		}
+		fieldVal.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fieldVal.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid integer %q: %w", name, raw, err)
+		}
+		fieldVal.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fieldVal.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid unsigned integer %q: %w", name, raw, err)
+		}
+		fieldVal.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fieldVal.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("environment variable %q: invalid float %q: %w", name, raw, err)
+		}
+		fieldVal.SetFloat(f)
+	default:
+		return fmt.Errorf("environment variable %q: unsupported field kind %s", name, fieldVal.Kind())
+	}
+	return nil
+}
+
+// parseTag splits a raw `env` tag value into its name and directives, e.g.
+// `NAME,required,default=foo,separator=;`.
+func parseTag(raw string) tagOptions {
+	parts := strings.Split(raw, ",")
+	opts := tagOptions{name: parts[0]}
+	for _, part := range parts[1:] {
+		switch {
+		case part == "required":
+			opts.required = true
+		case strings.HasPrefix(part, "default="):
+			opts.hasDefault = true
+			opts.defaultVal = strings.TrimPrefix(part, "default=")
+		case strings.HasPrefix(part, "separator="):
+			opts.separator = strings.TrimPrefix(part, "separator=")
+		}
+	}
+	return opts
+}
@@ -0,0 +1,131 @@
+package envload
+
+import (
+	"testing"
+	"time"
+)
+
+type staticSource map[string]string
+
+func (s staticSource) Lookup(key string) (string, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+type Nested struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT,default=8080"`
+}
+
+type spec struct {
+	Name     string        `env:"NAME,required"`
+	Count    int           `env:"COUNT"`
+	Tags     []string      `env:"TAGS,separator=;"`
+	Timeout  time.Duration `env:"TIMEOUT"`
+	Started  time.Time     `env:"STARTED"`
+	Nested   Nested
+	NestedP  *Nested
+	Untagged string
+	Ignored  string `env:"-"`
+}
+
+func TestProcessFromPopulatesScalarsAndNested(t *testing.T) {
+	src := staticSource{
+		"NAME":    "svc",
+		"COUNT":   "3",
+		"TAGS":    "a;b;c",
+		"TIMEOUT": "1500ms",
+		"STARTED": "2024-01-02T03:04:05Z",
+		"HOST":    "db.local",
+	}
+
+	var s spec
+	if err := ProcessFrom(&s, src); err != nil {
+		t.Fatalf("ProcessFrom returned error: %v", err)
+	}
+
+	if s.Name != "svc" {
+		t.Errorf("Name = %q, want %q", s.Name, "svc")
+	}
+	if s.Count != 3 {
+		t.Errorf("Count = %d, want 3", s.Count)
+	}
+	if got := s.Tags; len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", got)
+	}
+	if s.Timeout != 1500*time.Millisecond {
+		t.Errorf("Timeout = %v, want 1.5s", s.Timeout)
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !s.Started.Equal(want) {
+		t.Errorf("Started = %v, want %v", s.Started, want)
+	}
+	if s.Nested.Host != "db.local" {
+		t.Errorf("Nested.Host = %q, want %q", s.Nested.Host, "db.local")
+	}
+	if s.Nested.Port != 8080 {
+		t.Errorf("Nested.Port = %d, want default 8080", s.Nested.Port)
+	}
+	if s.NestedP == nil {
+		t.Fatalf("NestedP was not allocated")
+	}
+	if s.NestedP.Port != 8080 {
+		t.Errorf("NestedP.Port = %d, want default 8080", s.NestedP.Port)
+	}
+}
+
+func TestProcessFromRequiredMissingReportsFieldPath(t *testing.T) {
+	var s spec
+	err := ProcessFrom(&s, staticSource{})
+	if err == nil {
+		t.Fatal("expected an error for missing required field, got nil")
+	}
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("error is %T, want Errors", err)
+	}
+	found := false
+	for _, fe := range errs {
+		if fe.path == "Name" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Errors %v does not mention missing field \"Name\"", errs)
+	}
+}
+
+func TestProcessFromRejectsNonPointer(t *testing.T) {
+	if err := ProcessFrom(spec{}); err == nil {
+		t.Fatal("expected an error for a non-pointer spec, got nil")
+	}
+}
+
+type customField struct {
+	raw string
+}
+
+// UnmarshalEnv is declared on a pointer receiver, the idiomatic way to
+// implement an interface on an exported type -- this is the shape that
+// previously defeated implementsUnmarshaler for *customField fields.
+func (c *customField) UnmarshalEnv(b []byte) error {
+	c.raw = string(b)
+	return nil
+}
+
+type withPointerUnmarshaler struct {
+	Custom *customField `env:"CUSTOM"`
+}
+
+func TestProcessFromCallsUnmarshalEnvOnPointerField(t *testing.T) {
+	var s withPointerUnmarshaler
+	if err := ProcessFrom(&s, staticSource{"CUSTOM": "hello"}); err != nil {
+		t.Fatalf("ProcessFrom returned error: %v", err)
+	}
+	if s.Custom == nil {
+		t.Fatal("Custom was left nil; UnmarshalEnv was never called")
+	}
+	if s.Custom.raw != "hello" {
+		t.Errorf("Custom.raw = %q, want %q", s.Custom.raw, "hello")
+	}
+}
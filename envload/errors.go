@@ -0,0 +1,31 @@
+package envload
+
+import "strings"
+
+// fieldError pairs a struct field path with the problem found while
+// resolving it, so callers can tell at a glance which field needs fixing.
+type fieldError struct {
+	path string
+	err  error
+}
+
+func (e fieldError) Error() string {
+	return e.path + ": " + e.err.Error()
+}
+
+// Errors aggregates every fieldError found during a single Process call.
+// Its Error method lists them one per line so a CLI or log line shows the
+// whole set of missing/invalid variables at once instead of just the first.
+type Errors []fieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *Errors) add(path string, err error) {
+	*e = append(*e, fieldError{path: path, err: err})
+}
@@ -0,0 +1,83 @@
+package envload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// JSONSource is a Source backed by a JSON file. Nested objects are
+// flattened, joining parent and child keys with "_" so `{"db": {"host":
+// "x"}}` resolves the same key as the `DB_HOST` env tag convention:
+// db.host -> DB_HOST.
+type JSONSource map[string]string
+
+// LoadJSON reads and flattens the JSON file at path.
+func LoadJSON(path string) (JSONSource, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("envload: loading JSON file %s: %w", path, err)
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("envload: parsing JSON file %s: %w", path, err)
+	}
+	values := make(JSONSource)
+	flattenJSON(tree, "", values)
+	return values, nil
+}
+
+// Lookup implements Source, matching keys case-insensitively against the
+// flattened, upper-cased JSON key so `db.host` in the file satisfies an
+// `env:"DB_HOST"` tag.
+func (j JSONSource) Lookup(key string) (string, bool) {
+	v, ok := j[strings.ToUpper(key)]
+	return v, ok
+}
+
+// flattenJSON walks tree, writing every leaf into out keyed by its
+// underscore-joined, upper-cased path.
+func flattenJSON(tree map[string]interface{}, prefix string, out JSONSource) {
+	for k, v := range tree {
+		key := strings.ToUpper(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch val := v.(type) {
+		case map[string]interface{}:
+			flattenJSON(val, key, out)
+		case []interface{}:
+			// Joined with the same separator setSlice falls back to when a
+			// field's tag doesn't specify its own, so a JSON/TOML array
+			// resolves the same way a delimited string env var would.
+			elems := make([]string, len(val))
+			for i, elem := range val {
+				elems[i] = scalarToString(elem)
+			}
+			out[key] = strings.Join(elems, defaultSeparator)
+		case nil:
+			// absent key, nothing to record
+		default:
+			out[key] = scalarToString(val)
+		}
+	}
+}
+
+// scalarToString renders a decoded JSON/TOML scalar the way setScalar would
+// expect to parse it back from a string.
+func scalarToString(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
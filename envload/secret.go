@@ -0,0 +1,31 @@
+package envload
+
+// SecretSource is implemented by providers that fetch values from a secret
+// manager rather than a local file, where a lookup can fail for reasons
+// beyond "key not set" (network error, permission denied, expired lease).
+// Wrap a SecretSource with SecretSourceAdapter to use it as a plain Source
+// in a Chain; errors are treated as a miss so the chain can fall through to
+// the next source.
+//
+// This package ships no concrete implementation; it is the seam a project
+// wires up against HashiCorp Vault, AWS SSM Parameter Store, or similar.
+type SecretSource interface {
+	LookupSecret(key string) (string, error)
+}
+
+// SecretSourceAdapter adapts a SecretSource to the Source interface so it
+// can be placed in a Chain alongside OS, DotEnv, JSONSource, and TOMLSource.
+type SecretSourceAdapter struct {
+	Secrets SecretSource
+}
+
+// Lookup implements Source. An error from the underlying SecretSource is
+// treated as a miss, not a fatal error, so the chain falls through to the
+// next source (typically OS{} or a default).
+func (a SecretSourceAdapter) Lookup(key string) (string, bool) {
+	v, err := a.Secrets.LookupSecret(key)
+	if err != nil {
+		return "", false
+	}
+	return v, true
+}
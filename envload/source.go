@@ -0,0 +1,34 @@
+package envload
+
+import "os"
+
+// Source is a named lookup of string values, used by ProcessFrom to resolve
+// each field's `env` tag. Lookup reports false when key is not present in
+// the source, as opposed to present-but-empty.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// Chain tries each Source in order and returns the first hit, letting
+// callers compose e.g. [dotenv, OS{}] in dev or [vault, OS{}] in prod
+// without changing struct definitions.
+type Chain []Source
+
+// Lookup implements Source by trying each source in order.
+func (c Chain) Lookup(key string) (string, bool) {
+	for _, source := range c {
+		if v, ok := source.Lookup(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// OS is a Source backed by the process environment via os.LookupEnv. It is
+// the implicit last resort when no other source is given to ProcessFrom.
+type OS struct{}
+
+// Lookup implements Source.
+func (OS) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
@@ -0,0 +1,152 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChainReturnsFirstHit(t *testing.T) {
+	chain := Chain{
+		staticSource{"A": "first"},
+		staticSource{"A": "second", "B": "only-here"},
+	}
+	if v, ok := chain.Lookup("A"); !ok || v != "first" {
+		t.Errorf("Lookup(A) = (%q, %v), want (first, true)", v, ok)
+	}
+	if v, ok := chain.Lookup("B"); !ok || v != "only-here" {
+		t.Errorf("Lookup(B) = (%q, %v), want (only-here, true)", v, ok)
+	}
+	if _, ok := chain.Lookup("MISSING"); ok {
+		t.Error("Lookup(MISSING) reported a hit, want a miss")
+	}
+}
+
+func TestOSLookupUsesProcessEnvironment(t *testing.T) {
+	t.Setenv("ENVLOAD_TEST_OS_VAR", "value")
+	v, ok := OS{}.Lookup("ENVLOAD_TEST_OS_VAR")
+	if !ok || v != "value" {
+		t.Errorf("Lookup = (%q, %v), want (value, true)", v, ok)
+	}
+}
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadDotEnvParsesCommentsQuotesAndInterpolation(t *testing.T) {
+	t.Setenv("ENVLOAD_TEST_HOST", "from-os")
+	path := writeTemp(t, ".env", `
+# a comment
+NAME="quoted value"
+RAW=unquoted
+BASE=${NAME}-suffix
+FROM_OS=${ENVLOAD_TEST_HOST}
+`)
+	d, err := LoadDotEnv(path)
+	if err != nil {
+		t.Fatalf("LoadDotEnv: %v", err)
+	}
+	cases := map[string]string{
+		"NAME":    "quoted value",
+		"RAW":     "unquoted",
+		"BASE":    "quoted value-suffix",
+		"FROM_OS": "from-os",
+	}
+	for key, want := range cases {
+		got, ok := d.Lookup(key)
+		if !ok || got != want {
+			t.Errorf("Lookup(%s) = (%q, %v), want (%q, true)", key, got, ok, want)
+		}
+	}
+}
+
+func TestLoadDotEnvRejectsMalformedLine(t *testing.T) {
+	path := writeTemp(t, ".env", "NOT_KEY_VALUE\n")
+	if _, err := LoadDotEnv(path); err == nil {
+		t.Fatal("expected an error for a malformed line, got nil")
+	}
+}
+
+func TestLoadJSONFlattensNestedObjectsAndArrays(t *testing.T) {
+	path := writeTemp(t, "config.json", `{
+		"db": {"host": "db.local", "port": 5432},
+		"tags": ["a", "b", "c"],
+		"debug": true
+	}`)
+	src, err := LoadJSON(path)
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+	cases := map[string]string{
+		"DB_HOST": "db.local",
+		"DB_PORT": "5432",
+		"TAGS":    "a,b,c",
+		"DEBUG":   "true",
+	}
+	for key, want := range cases {
+		got, ok := src.Lookup(key)
+		if !ok || got != want {
+			t.Errorf("Lookup(%s) = (%q, %v), want (%q, true)", key, got, ok, want)
+		}
+	}
+}
+
+func TestLoadTOMLFlattensNestedTablesAndArrays(t *testing.T) {
+	path := writeTemp(t, "config.toml", `
+debug = true
+tags = ["a", "b", "c"]
+
+[db]
+host = "db.local"
+port = 5432
+`)
+	src, err := LoadTOML(path)
+	if err != nil {
+		t.Fatalf("LoadTOML: %v", err)
+	}
+	cases := map[string]string{
+		"DB_HOST": "db.local",
+		"DB_PORT": "5432",
+		"TAGS":    "a,b,c",
+		"DEBUG":   "true",
+	}
+	for key, want := range cases {
+		got, ok := src.Lookup(key)
+		if !ok || got != want {
+			t.Errorf("Lookup(%s) = (%q, %v), want (%q, true)", key, got, ok, want)
+		}
+	}
+}
+
+type stubSecrets struct {
+	values map[string]string
+	err    error
+}
+
+func (s stubSecrets) LookupSecret(key string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	v, ok := s.values[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+func TestSecretSourceAdapterTreatsErrorAsMiss(t *testing.T) {
+	adapter := SecretSourceAdapter{Secrets: stubSecrets{values: map[string]string{"TOKEN": "secret"}}}
+	if v, ok := adapter.Lookup("TOKEN"); !ok || v != "secret" {
+		t.Errorf("Lookup(TOKEN) = (%q, %v), want (secret, true)", v, ok)
+	}
+	if _, ok := adapter.Lookup("MISSING"); ok {
+		t.Error("Lookup(MISSING) reported a hit, want a miss since the secret store errors")
+	}
+}
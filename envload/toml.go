@@ -0,0 +1,39 @@
+package envload
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLSource is a Source backed by a TOML file, flattened the same way as
+// JSONSource: nested tables join their key with the parent using "_", e.g.
+// `[db] host = "x"` resolves the same key as an `env:"DB_HOST"` tag.
+type TOMLSource map[string]string
+
+// LoadTOML reads and flattens the TOML file at path.
+func LoadTOML(path string) (TOMLSource, error) {
+	var tree map[string]interface{}
+	if _, err := toml.DecodeFile(path, &tree); err != nil {
+		return nil, fmt.Errorf("envload: loading TOML file %s: %w", path, err)
+	}
+	values := make(TOMLSource)
+	flattenTOML(tree, "", values)
+	return values, nil
+}
+
+// Lookup implements Source, matching keys case-insensitively like
+// JSONSource.
+func (t TOMLSource) Lookup(key string) (string, bool) {
+	v, ok := t[strings.ToUpper(key)]
+	return v, ok
+}
+
+// flattenTOML walks tree, writing every leaf into out keyed by its
+// underscore-joined, upper-cased path. It reuses flattenJSON's conversion
+// rules since toml.DecodeFile into map[string]interface{} produces the same
+// shape of Go values as encoding/json for our purposes.
+func flattenTOML(tree map[string]interface{}, prefix string, out TOMLSource) {
+	flattenJSON(tree, prefix, JSONSource(out))
+}
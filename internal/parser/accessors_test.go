@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAccessorsCoversTopLevelAndNestedPointerFields(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type DBConfig struct {
+	Host string `+"`env:\"HOST\"`"+`
+	Port *int   `+"`env:\"PORT\"`"+`
+}
+
+type AppConfig struct {
+	DB      DBConfig
+	Name    *string `+"`env:\"NAME\"`"+`
+	Skipped *string `+"`env:\"SKIP_ENV\" accessor:\"-\"`"+`
+	Secret  *string `+"`env:\"-\"`"+`
+	Plain   string
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	out, err := GenerateAccessors(ty, Blacklist{})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	src := string(out)
+
+	for _, want := range []string{
+		"func (t *AppConfig) GetDBPort() int {",
+		"t.DB.Port == nil",
+		"return *t.DB.Port",
+		"func (t *AppConfig) GetName() string {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated source missing %q\n---\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{"GetSkipped", "GetSecret", "GetPlain"} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("generated source should not contain %q (opted out or not a pointer)\n---\n%s", unwanted, src)
+		}
+	}
+}
+
+func TestGenerateAccessorsHonorsNestedOptOutTag(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type DBConfig struct {
+	Host   string  `+"`env:\"HOST\"`"+`
+	Secret *string `+"`env:\"-\"`"+`
+}
+
+type AppConfig struct {
+	DB DBConfig
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	out, err := GenerateAccessors(ty, Blacklist{})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	if strings.Contains(string(out), "GetDBSecret") {
+		t.Errorf("nested env:\"-\" field should not get an accessor, got:\n%s", out)
+	}
+}
+
+// TestGeneratedAccessorsNilCheckEveryPointerAncestor compiles and runs the
+// generated code against a zero-valued struct whose intermediate field (DB)
+// is itself a nil pointer, guarding against a panic from dereferencing it
+// before the nil check reaches the leaf field.
+func TestGeneratedAccessorsNilCheckEveryPointerAncestor(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available on PATH")
+	}
+
+	dir := t.TempDir()
+	structsSrc := `package main
+
+type DBConfig struct {
+	Port *int ` + "`env:\"PORT\"`" + `
+}
+
+type AppConfig struct {
+	DB *DBConfig
+}
+`
+	path := filepath.Join(dir, "structs.go")
+	if err := os.WriteFile(path, []byte(structsSrc), 0o644); err != nil {
+		t.Fatalf("writing structs.go: %v", err)
+	}
+
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	accessors, err := GenerateAccessors(ty, Blacklist{})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "accessors.go"), accessors, 0o644); err != nil {
+		t.Fatalf("writing accessors.go: %v", err)
+	}
+
+	mainSrc := `package main
+
+import "fmt"
+
+func main() {
+	cfg := &AppConfig{}
+	fmt.Println(cfg.GetDBPort())
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(mainSrc), 0o644); err != nil {
+		t.Fatalf("writing main.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module accessortest\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatalf("writing go.mod: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run failed (likely a nil-pointer panic reaching into the nil DB field): %v\n%s", err, out)
+	}
+	got := strings.TrimSpace(string(out))
+	if got != "0" {
+		t.Errorf("generated accessor output = %q, want %q", got, "0")
+	}
+}
+
+func TestGenerateAccessorsHonorsBlacklist(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type AppConfig struct {
+	Name  *string `+"`env:\"NAME\"`"+`
+	Extra *string `+"`env:\"EXTRA\"`"+`
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	out, err := GenerateAccessors(ty, Blacklist{"AppConfig.Extra": true})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	src := string(out)
+	if !strings.Contains(src, "GetName") {
+		t.Errorf("generated source missing GetName\n---\n%s", src)
+	}
+	if strings.Contains(src, "GetExtra") {
+		t.Errorf("generated source should not contain blacklisted GetExtra\n---\n%s", src)
+	}
+}
+
+func TestGenerateAccessorsHonorsStructBlacklist(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type AppConfig struct {
+	Name *string `+"`env:\"NAME\"`"+`
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	out, err := GenerateAccessors(ty, Blacklist{"AppConfig": true})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	if strings.Contains(string(out), "func (t *AppConfig)") {
+		t.Errorf("struct-level blacklist entry should suppress every accessor, got:\n%s", out)
+	}
+}
+
+// TestGeneratedAccessorsActuallyRun compiles the generated accessor source
+// together with the struct it describes and runs it, so a regression in the
+// generated code (bad syntax, wrong nil check, wrong dereference) is caught
+// even if the generated text still happens to contain the substrings the
+// other tests look for.
+func TestGeneratedAccessorsActuallyRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available on PATH")
+	}
+
+	dir := t.TempDir()
+	structsSrc := `package main
+
+type DBConfig struct {
+	Host string ` + "`env:\"HOST\"`" + `
+	Port *int   ` + "`env:\"PORT\"`" + `
+}
+
+type AppConfig struct {
+	DB DBConfig
+}
+`
+	path := filepath.Join(dir, "structs.go")
+	if err := os.WriteFile(path, []byte(structsSrc), 0o644); err != nil {
+		t.Fatalf("writing structs.go: %v", err)
+	}
+
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	accessors, err := GenerateAccessors(ty, Blacklist{})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "accessors.go"), accessors, 0o644); err != nil {
+		t.Fatalf("writing accessors.go: %v", err)
+	}
+
+	mainSrc := `package main
+
+import "fmt"
+
+func main() {
+	var nilCfg *AppConfig
+	fmt.Println(nilCfg.GetDBPort())
+
+	port := 9090
+	cfg := &AppConfig{DB: DBConfig{Port: &port}}
+	fmt.Println(cfg.GetDBPort())
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(mainSrc), 0o644); err != nil {
+		t.Fatalf("writing main.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module accessortest\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatalf("writing go.mod: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run failed: %v\n%s", err, out)
+	}
+	got := strings.TrimSpace(string(out))
+	want := "0\n9090"
+	if got != want {
+		t.Errorf("generated accessor output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// resolvedStruct is what the TypeResolver caches for a given package path
+// and struct name: enough to both recurse (node) and label the result
+// (Package).
+type resolvedStruct struct {
+	Package string
+	node    *ast.StructType
+}
+
+// TypeResolver loads and caches struct declarations from other packages, so
+// that expanding a nested field (e.g. a DB config block declared in an
+// imported package) only pays the cost of loading that package once no
+// matter how many times the struct is referenced.
+type TypeResolver struct {
+	cache map[string]*resolvedStruct
+}
+
+// NewTypeResolver returns a resolver with an empty cache.
+func NewTypeResolver() *TypeResolver {
+	return &TypeResolver{
+		cache: make(map[string]*resolvedStruct),
+	}
+}
+
+// Resolve returns the struct declaration for structName in pkgPath, loading
+// the owning package on first use and caching the struct found, keyed by
+// package path and struct name; a different struct of an already loaded
+// package loads the package again. dir is the directory of the
+// file being parsed, used to resolve pkgPath the same way `go build` would
+// from that location -- without it, packages.Load resolves relative to the
+// calling process's own working directory, which is wrong whenever Parse is
+// invoked from anywhere other than the package being parsed.
+func (r *TypeResolver) Resolve(pkgPath, structName, dir string) (*resolvedStruct, error) {
+	key := pkgPath + "." + structName
+	if rs, ok := r.cache[key]; ok {
+		return rs, nil
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("parser: loading package %s: %w", pkgPath, err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("parser: package %s not found", pkgPath)
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("parser: package %s has errors: %v", pkgPath, pkg.Errors[0])
+	}
+
+	var found *resolvedStruct
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(node ast.Node) bool {
+			ts, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st := getStruct(ts.Type); st != nil && ts.Name.String() == structName {
+				found = &resolvedStruct{Package: pkg.Name, node: st}
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("parser: struct %s not found in package %s", structName, pkgPath)
+	}
+	r.cache[key] = found
+	return found, nil
+}
@@ -0,0 +1,9 @@
+// Package appconfig is a fixture for TestParseExpandsStructFromImportedPackage.
+package appconfig
+
+import "github.com/Sweta271097/go-envparser/internal/parser/testdata/crosspkg/dbconfig"
+
+// AppConfig has a field whose type is declared in an imported package.
+type AppConfig struct {
+	DB dbconfig.DBConfig
+}
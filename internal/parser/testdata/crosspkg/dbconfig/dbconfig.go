@@ -0,0 +1,8 @@
+// Package dbconfig is a fixture for TestParseExpandsStructFromImportedPackage:
+// a struct declared in its own package, imported by appconfig.
+package dbconfig
+
+// DBConfig holds the fields appconfig.AppConfig expands into its own Fields.
+type DBConfig struct {
+	Host string `env:"HOST"`
+}
@@ -1,11 +1,13 @@
 package parser
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"go/types"
 	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"strings"
 )
@@ -16,6 +18,13 @@ type Type struct {
 	Name     string
 	Fields   []Field
 	Package  string
+
+	// FileSet is the token.FileSet the struct was parsed with, retained so
+	// Validate can turn a field's token.Pos into a readable token.Position.
+	FileSet *token.FileSet
+	// node is the raw, unexpanded struct declaration, used by Validate to
+	// check the tags as written rather than the flattened Fields.
+	node *ast.StructType
 }
 
 // Field encapsulates the struct field metadata needed for the template execution
@@ -25,6 +34,24 @@ type Field struct {
 	EnvTag    string
 	IsPointer bool
 	IsArray   bool
+	// Path holds the chain of field names from the root struct down to this
+	// field, e.g. []string{"DB", "Host"} for a nested `DB.Host` field, so the
+	// generated code can traverse cfg.DB.Host = ... instead of assuming every
+	// field lives on the top-level struct.
+	Path []string
+	// SkipAccessor is set when the field carries an `accessor:"-"` tag,
+	// opting it out of GenerateAccessors even though IsPointer is true.
+	SkipAccessor bool
+	// Skip is set when the field carries an `env:"-"` tag, opting it out of
+	// env binding entirely. It mirrors the raw tag rather than EnvTag, which
+	// may have a prefix prepended by a nested struct and so no longer equals
+	// the literal "-".
+	Skip bool
+	// PointerAncestors holds the dotted Path of every pointer-to-struct hop
+	// strictly between the root struct and this field, in traversal order,
+	// e.g. []string{"DB"} for a field at DB.Port where DB is *DBConfig. Code
+	// generated off Path must nil-check each of these before the leaf itself.
+	PointerAncestors []string
 }
 
 // NewType returns a new instance of Type with given name
@@ -44,7 +71,12 @@ func getStruct(nodeType ast.Node) *ast.StructType {
 	}
 }
 
-// Parse parses Type metadata from given file using go parser & ast
+// Parse parses Type metadata from given file using go parser & ast. Fields
+// whose type is another struct declared in the same file, or in a package the
+// file imports (loaded via a TypeResolver), are expanded recursively, so the
+// resulting Fields list is already flattened with env-prefix propagation and
+// full Path information applied. A struct declared in another file of the
+// same package is not looked up, so a field of that type stays a single leaf.
 func (t *Type) Parse(fileName string) error {
 	t.FileName = fileName
 	fset := token.NewFileSet()
@@ -56,6 +88,9 @@ func (t *Type) Parse(fileName string) error {
 	if err != nil {
 		return err
 	}
+
+	var parseErr error
+	resolver := NewTypeResolver()
 	// Look up the AST
 	ast.Inspect(f, func(node ast.Node) bool {
 		switch nodeType := node.(type) {
@@ -65,47 +100,226 @@ func (t *Type) Parse(fileName string) error {
 				if t.Name == nodeType.Name.String() {
 					// Helper to populate struct's field and tags info
 					t.Package = f.Name.String()
-					t.Fields = getFields(node)
+					t.FileSet = fset
+					t.node = node
+					ctx := &expandCtx{
+						fset:     fset,
+						file:     f,
+						dir:      filepath.Dir(fileName),
+						resolver: resolver,
+						visiting: map[string]bool{t.Package + "." + t.Name: true},
+					}
+					fields, err := expandFields(node, nil, "", nil, ctx)
+					if err != nil {
+						parseErr = err
+						return false
+					}
+					t.Fields = fields
 					return false
 				}
 			}
 		}
 		return true
 	})
-	return nil
+	return parseErr
+}
+
+// expandCtx carries the state needed while recursively flattening nested
+// struct fields: the FileSet/File used to resolve locally declared structs,
+// dir (the directory of the file being parsed, for resolving imports the way
+// `go build` would), the resolver used for structs declared in imported
+// packages, and the set of struct keys currently being expanded, used to
+// detect cycles.
+type expandCtx struct {
+	fset     *token.FileSet
+	file     *ast.File
+	dir      string
+	resolver *TypeResolver
+	visiting map[string]bool
 }
 
-// getFields will transforms the field metadata returned by go ast to the template's format
-func getFields(node *ast.StructType) []Field {
+// expandFields transforms the field metadata returned by go ast to the
+// template's format, recursing into nested structs and joining envPrefix
+// tags with the child's env tag as it goes.
+func expandFields(node *ast.StructType, parentPath []string, prefix string, ptrAncestors []string, ctx *expandCtx) ([]Field, error) {
 	var fields []Field
 	for _, field := range node.Fields.List {
 		var tags reflect.StructTag
 		if field.Tag != nil {
 			tags = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
 		}
+		fieldType := types.ExprString(field.Type)
+
 		if len(field.Names) == 0 {
-			fieldType := types.ExprString(field.Type)
+			// Anonymous embedded field: inherit the parent prefix verbatim,
+			// with no separator and no extra path segment of its own.
+			expanded, ok, err := expandNestedField(fieldType, tags, "", parentPath, prefix, ptrAncestors, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				fields = append(fields, expanded...)
+				continue
+			}
 			fields = append(fields, Field{
-				Name:      "",
-				Type:      cleanTypeStr(fieldType),
-				EnvTag:    getEnvSourceTag(tags, fieldType),
-				IsPointer: isPointer(fieldType),
-				IsArray:   isArray(fieldType),
+				Name:             "",
+				Type:             cleanTypeStr(fieldType),
+				EnvTag:           getEnvSourceTag(tags, fieldType),
+				IsPointer:        isPointer(fieldType),
+				IsArray:          isArray(fieldType),
+				Path:             parentPath,
+				SkipAccessor:     skipAccessorTag(tags),
+				Skip:             envTagIsDash(tags),
+				PointerAncestors: ptrAncestors,
 			})
 			continue
 		}
+
 		for _, fieldName := range field.Names {
-			fieldType := types.ExprString(field.Type)
+			expanded, ok, err := expandNestedField(fieldType, tags, fieldName.Name, parentPath, prefix, ptrAncestors, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				fields = append(fields, expanded...)
+				continue
+			}
+			path := append(append([]string{}, parentPath...), fieldName.Name)
 			fields = append(fields, Field{
-				Name:      fieldName.Name,
-				Type:      cleanTypeStr(fieldType),
-				IsPointer: isPointer(fieldType),
-				IsArray:   isArray(fieldType),
-				EnvTag:    getEnvSourceTag(tags, fieldName.Name),
+				Name:             fieldName.Name,
+				Type:             cleanTypeStr(fieldType),
+				IsPointer:        isPointer(fieldType),
+				IsArray:          isArray(fieldType),
+				EnvTag:           prefix + getEnvSourceTag(tags, fieldName.Name),
+				Path:             path,
+				SkipAccessor:     skipAccessorTag(tags),
+				Skip:             envTagIsDash(tags),
+				PointerAncestors: ptrAncestors,
 			})
 		}
 	}
-	return fields
+	return fields, nil
+}
+
+// expandNestedField checks whether fieldType refers to a struct declared in
+// the same file or in an imported package and, if so, resolves it and
+// recurses. ok is false when fieldType is not a resolvable struct, in which
+// case the caller should treat the field as a plain leaf.
+func expandNestedField(fieldType string, tags reflect.StructTag, fieldName string, parentPath []string, prefix string, ptrAncestors []string, ctx *expandCtx) ([]Field, bool, error) {
+	bareType := cleanTypeStr(fieldType)
+	structNode, key, found := resolveStruct(bareType, ctx)
+	if !found {
+		return nil, false, nil
+	}
+	if ctx.visiting[key] {
+		return nil, true, fmt.Errorf("parser: cycle detected expanding %s", key)
+	}
+
+	childPath := parentPath
+	childPrefix := prefix
+	if fieldName != "" {
+		childPath = append(append([]string{}, parentPath...), fieldName)
+		childPrefix = prefix + nestedPrefix(tags, fieldName)
+	}
+
+	childPtrAncestors := ptrAncestors
+	if isPointer(fieldType) {
+		childPtrAncestors = append(append([]string{}, ptrAncestors...), strings.Join(childPath, "."))
+	}
+
+	ctx.visiting[key] = true
+	defer delete(ctx.visiting, key)
+
+	fields, err := expandFields(structNode, childPath, childPrefix, childPtrAncestors, ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	return fields, true, nil
+}
+
+// nestedPrefix returns the env-var prefix a nested struct field contributes:
+// the field's envPrefix tag if present, otherwise the upper-cased field name
+// followed by an underscore, mirroring getEnvSourceTag's default.
+func nestedPrefix(tags reflect.StructTag, fieldName string) string {
+	if p, ok := tags.Lookup("envPrefix"); ok {
+		return p
+	}
+	return strings.ToUpper(fieldName) + "_"
+}
+
+// resolveStruct looks up bareType as a struct declared in the current file
+// first, falling back to an imported package via the TypeResolver when
+// bareType is qualified as pkg.Name. found is false when bareType isn't a
+// struct declared in this file or an imported package (built-ins, for
+// instance), and for time.Time, which is kept as a single field.
+func resolveStruct(bareType string, ctx *expandCtx) (node *ast.StructType, key string, found bool) {
+	if strings.Contains(bareType, ".") {
+		parts := strings.SplitN(bareType, ".", 2)
+		alias, name := parts[0], parts[1]
+		importPath, ok := importPathForAlias(ctx.file, alias)
+		if !ok {
+			return nil, "", false
+		}
+		if importPath == "time" && name == "Time" {
+			return nil, "", false
+		}
+		resolved, err := ctx.resolver.Resolve(importPath, name, ctx.dir)
+		if err != nil || resolved == nil {
+			return nil, "", false
+		}
+		return resolved.node, importPath + "." + name, true
+	}
+
+	node = lookupLocalStruct(ctx.file, bareType)
+	if node == nil {
+		return nil, "", false
+	}
+	return node, ctx.file.Name.String() + "." + bareType, true
+}
+
+// lookupLocalStruct returns the *ast.StructType declared as name in file, or
+// nil if no such struct declaration exists there.
+func lookupLocalStruct(file *ast.File, name string) *ast.StructType {
+	var found *ast.StructType
+	ast.Inspect(file, func(node ast.Node) bool {
+		ts, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		if st := getStruct(ts.Type); st != nil && ts.Name.String() == name {
+			found = st
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// importPathForAlias returns the import path registered under alias in
+// file's imports, matching either an explicit import alias or the package's
+// default name (the last path segment).
+func importPathForAlias(file *ast.File, alias string) (string, bool) {
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if imp.Name != nil {
+			if imp.Name.String() == alias {
+				return path, true
+			}
+			continue
+		}
+		if lastSegment(path) == alias {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// lastSegment returns the final "/"-separated component of an import path.
+func lastSegment(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
 }
 
 // isPointer checks if a given type is a pointer or not
@@ -127,6 +341,19 @@ func getEnvSourceTag(tags reflect.StructTag, fieldName string) string {
 	return tag
 }
 
+// skipAccessorTag reports whether a field opted out of accessor generation
+// via an explicit `accessor:"-"` tag.
+func skipAccessorTag(tags reflect.StructTag) bool {
+	return tags.Get("accessor") == "-"
+}
+
+// envTagIsDash reports whether a field opted out of env binding entirely via
+// an explicit `env:"-"` tag, as written -- i.e. before a parent struct's
+// prefix is prepended to EnvTag.
+func envTagIsDash(tags reflect.StructTag) bool {
+	return tags.Get("env") == "-"
+}
+
 // cleanTypeStr will strip all unwanted space and other characters to return the type name
 func cleanTypeStr(typ string) string {
 	typ = strings.TrimSpace(typ)
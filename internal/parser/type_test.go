@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSource(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.go")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+	return path
+}
+
+// fieldByName finds the first Field whose dotted Path matches dottedPath.
+func fieldByName(t *testing.T, fields []Field, dottedPath string) Field {
+	t.Helper()
+	for _, f := range fields {
+		if strings.Join(f.Path, ".") == dottedPath {
+			return f
+		}
+	}
+	t.Fatalf("no field with path %q in %+v", dottedPath, fields)
+	return Field{}
+}
+
+func TestParseFlatStructUnchanged(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Flat struct {
+	Name string `+"`env:\"NAME\"`"+`
+	Port int
+}
+`)
+	ty := NewType("Flat")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(ty.Fields) != 2 {
+		t.Fatalf("got %d fields, want 2: %+v", len(ty.Fields), ty.Fields)
+	}
+	name := fieldByName(t, ty.Fields, "Name")
+	if name.EnvTag != "NAME" {
+		t.Errorf("Name.EnvTag = %q, want %q", name.EnvTag, "NAME")
+	}
+	port := fieldByName(t, ty.Fields, "Port")
+	if port.EnvTag != "PORT" {
+		t.Errorf("Port.EnvTag = %q, want default %q", port.EnvTag, "PORT")
+	}
+}
+
+func TestParseExpandsNestedStructWithDefaultPrefix(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type DBConfig struct {
+	Host string `+"`env:\"HOST\"`"+`
+}
+
+type AppConfig struct {
+	DB DBConfig
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	host := fieldByName(t, ty.Fields, "DB.Host")
+	if host.EnvTag != "DB_HOST" {
+		t.Errorf("DB.Host EnvTag = %q, want %q", host.EnvTag, "DB_HOST")
+	}
+}
+
+func TestParseExpandsNestedStructWithExplicitEnvPrefix(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type DBConfig struct {
+	Host string `+"`env:\"HOST\"`"+`
+}
+
+type AppConfig struct {
+	Cache DBConfig `+"`envPrefix:\"CACHE_\"`"+`
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	host := fieldByName(t, ty.Fields, "Cache.Host")
+	if host.EnvTag != "CACHE_HOST" {
+		t.Errorf("Cache.Host EnvTag = %q, want %q", host.EnvTag, "CACHE_HOST")
+	}
+}
+
+func TestParseExpandsAnonymousEmbeddedWithoutExtraPrefix(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Base struct {
+	Env string `+"`env:\"ENV\"`"+`
+}
+
+type AppConfig struct {
+	Base
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	env := fieldByName(t, ty.Fields, "Env")
+	if env.EnvTag != "ENV" {
+		t.Errorf("embedded Env EnvTag = %q, want %q (no extra prefix)", env.EnvTag, "ENV")
+	}
+}
+
+func TestParseExpandsPointerToStructFields(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Address struct {
+	City string `+"`env:\"CITY\"`"+`
+}
+
+type AppConfig struct {
+	Addr *Address
+}
+`)
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	city := fieldByName(t, ty.Fields, "Addr.City")
+	if city.EnvTag != "ADDR_CITY" {
+		t.Errorf("Addr.City EnvTag = %q, want %q", city.EnvTag, "ADDR_CITY")
+	}
+}
+
+// TestParseExpandsStructFromImportedPackage guards against resolving the
+// imported package's source relative to the test process's own working
+// directory instead of the parsed file's directory: it chdirs somewhere
+// unrelated before calling Parse, so a regression would make the import
+// unresolvable and AppConfig would come back unexpanded.
+func TestParseExpandsStructFromImportedPackage(t *testing.T) {
+	path, err := filepath.Abs("testdata/crosspkg/appconfig/appconfig.go")
+	if err != nil {
+		t.Fatalf("filepath.Abs: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("os.Chdir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("restoring cwd: %v", err)
+		}
+	})
+
+	ty := NewType("AppConfig")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	host := fieldByName(t, ty.Fields, "DB.Host")
+	if host.EnvTag != "DB_HOST" {
+		t.Errorf("DB.Host EnvTag = %q, want %q", host.EnvTag, "DB_HOST")
+	}
+}
+
+func TestParseDetectsCycle(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Self struct {
+	Child *Self
+}
+`)
+	ty := NewType("Self")
+	err := ty.Parse(path)
+	if err == nil {
+		t.Fatal("expected a cycle error, got nil")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("error %q does not mention a cycle", err.Error())
+	}
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Diagnostic reports a single well-formedness problem found by Validate,
+// pinned to the source position of the offending field.
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+// validOptions are the bare keyword directives an env tag may carry (the
+// same set envload's parseTag reads); anything else after the env var name
+// is a typo worth flagging.
+var validOptions = map[string]bool{
+	"required": true,
+}
+
+// validOptionPrefixes are directives that carry a value, e.g. "default=foo".
+var validOptionPrefixes = []string{"default=", "separator="}
+
+// Validate runs before code generation and reports problems in t's `env`
+// tags: duplicate env names across fields of the struct, empty tag values,
+// invalid option keywords, default= combined with required, and env tags on
+// fields the generated setter has no way to assign. It does not fail fast;
+// every problem found is returned so a caller can report them all at once.
+// No command-line tool in this module calls Validate.
+func Validate(t *Type) []Diagnostic {
+	if t.node == nil {
+		return nil
+	}
+
+	var diags []Diagnostic
+	seen := make(map[string]token.Pos)
+	for _, field := range t.node.Fields.List {
+		var tags reflect.StructTag
+		if field.Tag != nil {
+			tags = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		raw, ok := tags.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		pos := field.Pos()
+		if raw == "" {
+			diags = append(diags, t.diag(pos, "empty env tag"))
+			continue
+		}
+
+		parts := strings.Split(raw, ",")
+		name := parts[0]
+		if name == "" {
+			diags = append(diags, t.diag(pos, "empty env tag"))
+		} else if name == "-" {
+			// "-" is the documented opt-out sentinel, not a real env name;
+			// multiple fields are allowed to carry it.
+		} else if prev, ok := seen[name]; ok {
+			diags = append(diags, t.diag(pos, fmt.Sprintf("duplicate env name %q (first used at %s)", name, t.position(prev))))
+		} else {
+			seen[name] = pos
+		}
+
+		var hasRequired, hasDefault bool
+		for _, opt := range parts[1:] {
+			switch {
+			case validOptions[opt]:
+				if opt == "required" {
+					hasRequired = true
+				}
+			case hasValidPrefix(opt):
+				if strings.HasPrefix(opt, "default=") {
+					hasDefault = true
+				}
+			default:
+				diags = append(diags, t.diag(pos, fmt.Sprintf("unknown env tag option %q", opt)))
+			}
+		}
+		if hasRequired && hasDefault {
+			diags = append(diags, t.diag(pos, "env tag has both \"required\" and \"default=\", which is contradictory"))
+		}
+
+		for _, fieldName := range field.Names {
+			if !isExported(fieldName.Name) {
+				diags = append(diags, t.diag(pos, fmt.Sprintf("env tag on unexported field %q: the generated setter cannot assign it", fieldName.Name)))
+			}
+		}
+	}
+	return diags
+}
+
+// diag builds a Diagnostic, resolving pos against t.FileSet when available.
+func (t *Type) diag(pos token.Pos, message string) Diagnostic {
+	return Diagnostic{Pos: t.position(pos), Message: message}
+}
+
+// position resolves pos against t.FileSet, falling back to a zero Position
+// when the FileSet wasn't retained (e.g. a Type built without Parse).
+func (t *Type) position(pos token.Pos) token.Position {
+	if t.FileSet == nil {
+		return token.Position{}
+	}
+	return t.FileSet.Position(pos)
+}
+
+// hasValidPrefix reports whether opt starts with one of validOptionPrefixes.
+func hasValidPrefix(opt string) bool {
+	for _, prefix := range validOptionPrefixes {
+		if strings.HasPrefix(opt, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExported reports whether name starts with an upper-case letter, mirroring
+// the Go language rule for exported identifiers.
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
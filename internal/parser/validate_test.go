@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func messages(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.Message
+	}
+	return out
+}
+
+func containsSubstring(haystack []string, substr string) bool {
+	for _, s := range haystack {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateCleanStructHasNoDiagnostics(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Clean struct {
+	Name string `+"`env:\"NAME,required\"`"+`
+	Port int    `+"`env:\"PORT,default=8080\"`"+`
+}
+`)
+	ty := NewType("Clean")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if diags := Validate(ty); len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", diags)
+	}
+}
+
+func TestValidateFlagsDuplicateEnvNames(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Dup struct {
+	A string `+"`env:\"SAME\"`"+`
+	B string `+"`env:\"SAME\"`"+`
+}
+`)
+	ty := NewType("Dup")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if !containsSubstring(messages(diags), "duplicate env name") {
+		t.Errorf("diagnostics %v don't mention a duplicate env name", messages(diags))
+	}
+}
+
+func TestValidateFlagsEmptyTag(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Empty struct {
+	A string `+"`env:\"\"`"+`
+}
+`)
+	ty := NewType("Empty")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if !containsSubstring(messages(diags), "empty env tag") {
+		t.Errorf("diagnostics %v don't mention an empty env tag", messages(diags))
+	}
+}
+
+func TestValidateFlagsUnknownOption(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Bad struct {
+	A string `+"`env:\"A,bogus\"`"+`
+}
+`)
+	ty := NewType("Bad")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if !containsSubstring(messages(diags), `unknown env tag option "bogus"`) {
+		t.Errorf("diagnostics %v don't mention the unknown option", messages(diags))
+	}
+}
+
+func TestValidateFlagsRequiredWithDefault(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Contradiction struct {
+	A string `+"`env:\"A,required,default=x\"`"+`
+}
+`)
+	ty := NewType("Contradiction")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if !containsSubstring(messages(diags), "contradictory") {
+		t.Errorf("diagnostics %v don't flag the required+default conflict", messages(diags))
+	}
+}
+
+func TestValidateAllowsMultipleOptOutFields(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Cfg struct {
+	A string `+"`env:\"-\"`"+`
+	B string `+"`env:\"-\"`"+`
+}
+`)
+	ty := NewType("Cfg")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if containsSubstring(messages(diags), "duplicate env name") {
+		t.Errorf("opt-out fields should not be flagged as duplicates, got %v", messages(diags))
+	}
+}
+
+func TestValidateFlagsUnexportedField(t *testing.T) {
+	path := writeSource(t, `package sample
+
+type Unexported struct {
+	a string `+"`env:\"A\"`"+`
+}
+`)
+	ty := NewType("Unexported")
+	if err := ty.Parse(path); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	diags := Validate(ty)
+	if !containsSubstring(messages(diags), "unexported field") {
+		t.Errorf("diagnostics %v don't flag the unexported field", messages(diags))
+	}
+}